@@ -0,0 +1,136 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package export
+
+import (
+	"io"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/ipc"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/janvdl/go-xpt/xpt"
+)
+
+// arrowColumn is one output column: either a Variable's value column, or (for
+// a NUMERIC Variable that has at least one special missing value) the
+// "<name>_missing" sidecar column carrying that value's SAS missing code.
+type arrowColumn struct {
+	field   arrow.Field
+	builder array.Builder
+	varIdx  int
+	sidecar bool
+}
+
+// ToArrowIPC writes ds as a single Arrow IPC stream with one record batch.
+// NUMERIC variables become nullable float64 columns; a column that contains
+// any special missing value also gets a sidecar "<name>_missing" string
+// column (empty unless that row's code is non-default), matching ToParquet.
+// CHARACTER variables become UTF-8 string columns.
+func ToArrowIPC(w io.Writer, ds *xpt.Dataset) error {
+	pool := memory.NewGoAllocator()
+
+	hasMissing := make([]bool, len(ds.Vars))
+	for i, v := range ds.Vars {
+		if v.Type() != xpt.NUMERIC {
+			continue
+		}
+		for _, cells := range ds.Rows {
+			if cells[i].IsMissing {
+				hasMissing[i] = true
+				break
+			}
+		}
+	}
+
+	var columns []arrowColumn
+	for i, v := range ds.Vars {
+		if v.Type() == xpt.NUMERIC {
+			columns = append(columns, arrowColumn{
+				field:   arrow.Field{Name: v.Name(), Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+				builder: array.NewFloat64Builder(pool),
+				varIdx:  i,
+			})
+			if hasMissing[i] {
+				columns = append(columns, arrowColumn{
+					field:   arrow.Field{Name: v.Name() + "_missing", Type: arrow.BinaryTypes.String},
+					builder: array.NewStringBuilder(pool),
+					varIdx:  i,
+					sidecar: true,
+				})
+			}
+		} else {
+			columns = append(columns, arrowColumn{
+				field:   arrow.Field{Name: v.Name(), Type: arrow.BinaryTypes.String},
+				builder: array.NewStringBuilder(pool),
+				varIdx:  i,
+			})
+		}
+	}
+	defer func() {
+		for _, c := range columns {
+			c.builder.Release()
+		}
+	}()
+
+	for _, cells := range ds.Rows {
+		for _, c := range columns {
+			d := cells[c.varIdx]
+
+			switch {
+			case c.sidecar:
+				sb := c.builder.(*array.StringBuilder)
+				if d.IsMissing && d.MissingCode != 0 {
+					sb.Append(missingCodeLabel(d.MissingCode))
+				} else {
+					sb.Append("")
+				}
+			case ds.Vars[c.varIdx].Type() == xpt.NUMERIC:
+				fb := c.builder.(*array.Float64Builder)
+				if d.IsMissing {
+					fb.AppendNull()
+				} else {
+					fb.Append(d.Float64())
+				}
+			default:
+				c.builder.(*array.StringBuilder).Append(d.String())
+			}
+		}
+	}
+
+	fields := make([]arrow.Field, len(columns))
+	cols := make([]arrow.Array, len(columns))
+	for i, c := range columns {
+		fields[i] = c.field
+		cols[i] = c.builder.NewArray()
+		defer cols[i].Release()
+	}
+
+	schema := arrow.NewSchema(fields, nil)
+	record := array.NewRecord(schema, cols, int64(len(ds.Rows)))
+	defer record.Release()
+
+	writer := ipc.NewWriter(w, ipc.WithSchema(schema))
+	if err := writer.Write(record); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
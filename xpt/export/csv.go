@@ -0,0 +1,96 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// Package export bridges parsed xpt.Dataset values to modern columnar and
+// tabular formats: CSV, Parquet and Arrow IPC.
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/janvdl/go-xpt/xpt"
+)
+
+// ToCSV writes ds as a header row of variable names followed by one row per
+// observation. NUMERIC cells are formatted with strconv.FormatFloat; a
+// numeric column that contains any special missing value also gets a sidecar
+// "<name>_missing" column (empty unless that row's code is non-default) so
+// the SAS missing semantics survive, matching ToParquet/ToArrowIPC. CHARACTER
+// cells are written as-is.
+func ToCSV(w io.Writer, ds *xpt.Dataset) error {
+	cw := csv.NewWriter(w)
+
+	hasMissing := make([]bool, len(ds.Vars))
+	for i, v := range ds.Vars {
+		if v.Type() != xpt.NUMERIC {
+			continue
+		}
+		for _, cells := range ds.Rows {
+			if cells[i].IsMissing {
+				hasMissing[i] = true
+				break
+			}
+		}
+	}
+
+	var header []string
+	for i, v := range ds.Vars {
+		header = append(header, v.Name())
+		if hasMissing[i] {
+			header = append(header, v.Name()+"_missing")
+		}
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(header))
+	for _, cells := range ds.Rows {
+		col := 0
+		for i, v := range ds.Vars {
+			d := cells[i]
+			if v.Type() == xpt.NUMERIC {
+				if d.IsMissing {
+					row[col] = ""
+				} else {
+					row[col] = strconv.FormatFloat(d.Float64(), 'g', -1, 64)
+				}
+				col++
+				if hasMissing[i] {
+					if d.IsMissing && d.MissingCode != 0 {
+						row[col] = missingCodeLabel(d.MissingCode)
+					} else {
+						row[col] = ""
+					}
+					col++
+				}
+			} else {
+				row[col] = d.String()
+				col++
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
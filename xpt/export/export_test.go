@@ -0,0 +1,154 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow/ipc"
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/janvdl/go-xpt/xpt"
+)
+
+func testDataset() *xpt.Dataset {
+	return &xpt.Dataset{
+		Vars: []xpt.Variable{
+			xpt.NewVariable(1, "WEIGHT", "Weight in kg", 8, xpt.NUMERIC),
+			xpt.NewVariable(2, "CITY", "City", 12, xpt.CHARACTER),
+		},
+		Rows: [][]xpt.DataCell{
+			{xpt.NewNumericCell(72.5), xpt.NewCharacterCell("Paris")},
+			{xpt.NewMissingCell('A'), xpt.NewCharacterCell("Berlin")},
+		},
+	}
+}
+
+func TestToCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ToCSV(&buf, testDataset()); err != nil {
+		t.Fatalf("ToCSV: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3 (header + 2 rows): %q", len(lines), buf.String())
+	}
+	if lines[0] != "WEIGHT,WEIGHT_missing,CITY" {
+		t.Errorf("header = %q, want WEIGHT,WEIGHT_missing,CITY", lines[0])
+	}
+	if lines[1] != "72.5,,Paris" {
+		t.Errorf("row 0 = %q, want 72.5,,Paris", lines[1])
+	}
+	if lines[2] != ",.A,Berlin" {
+		t.Errorf("row 1 = %q, want ,.A,Berlin (missing WEIGHT, code A)", lines[2])
+	}
+}
+
+// TestToCSVNoMissingNoSidecar confirms ToCSV only adds a "<name>_missing"
+// column for variables that actually have a missing value, matching
+// ToParquet/ToArrowIPC's behavior.
+func TestToCSVNoMissingNoSidecar(t *testing.T) {
+	ds := &xpt.Dataset{
+		Vars: []xpt.Variable{
+			xpt.NewVariable(1, "WEIGHT", "Weight in kg", 8, xpt.NUMERIC),
+		},
+		Rows: [][]xpt.DataCell{
+			{xpt.NewNumericCell(50)},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ToCSV(&buf, ds); err != nil {
+		t.Fatalf("ToCSV: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "WEIGHT" {
+		t.Errorf("header = %q, want WEIGHT (no sidecar column)", lines[0])
+	}
+}
+
+func TestToParquet(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ToParquet(&buf, testDataset()); err != nil {
+		t.Fatalf("ToParquet: %v", err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("parquet.OpenFile: %v", err)
+	}
+
+	rows := make([]map[string]any, 0, 2)
+	reader := parquet.NewGenericReader[map[string]any](f, f.Schema())
+	defer reader.Close()
+	buf2 := make([]map[string]any, 2)
+	for i := range buf2 {
+		buf2[i] = map[string]any{}
+	}
+	n, err := reader.Read(buf2)
+	if err != nil && n == 0 {
+		t.Fatalf("reader.Read: %v", err)
+	}
+	rows = append(rows, buf2[:n]...)
+
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0]["CITY"] != "Paris" {
+		t.Errorf("row 0 CITY = %v, want Paris", rows[0]["CITY"])
+	}
+	if rows[1]["WEIGHT"] != nil {
+		t.Errorf("row 1 WEIGHT = %v, want nil (missing)", rows[1]["WEIGHT"])
+	}
+	if rows[1]["WEIGHT_missing"] != ".A" {
+		t.Errorf("row 1 WEIGHT_missing = %v, want .A", rows[1]["WEIGHT_missing"])
+	}
+}
+
+func TestToArrowIPC(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ToArrowIPC(&buf, testDataset()); err != nil {
+		t.Fatalf("ToArrowIPC: %v", err)
+	}
+
+	rdr, err := ipc.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ipc.NewReader: %v", err)
+	}
+	defer rdr.Release()
+
+	if !rdr.Next() {
+		t.Fatalf("expected one record batch")
+	}
+	record := rdr.Record()
+
+	schema := record.Schema()
+	if _, ok := schema.FieldsByName("WEIGHT_missing"); !ok {
+		t.Fatalf("schema missing WEIGHT_missing sidecar column: %v", schema)
+	}
+
+	missingCol := record.Column(schema.FieldIndices("WEIGHT_missing")[0])
+	if got := missingCol.ValueStr(1); got != ".A" {
+		t.Errorf("WEIGHT_missing row 1 = %q, want .A", got)
+	}
+}
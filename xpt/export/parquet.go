@@ -0,0 +1,104 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package export
+
+import (
+	"io"
+
+	"github.com/janvdl/go-xpt/xpt"
+	"github.com/parquet-go/parquet-go"
+)
+
+// ToParquet writes ds as a single-row-group Parquet file. NUMERIC variables
+// become nullable DOUBLE columns; a numeric column that contains any special
+// missing value also gets a sidecar "<name>_missing" string column (empty
+// unless that row's code is non-default) so the SAS missing semantics
+// survive the round trip. CHARACTER variables become UTF-8 string columns.
+func ToParquet(w io.Writer, ds *xpt.Dataset) error {
+	hasMissing := make(map[string]bool, len(ds.Vars))
+	for i, v := range ds.Vars {
+		if v.Type() != xpt.NUMERIC {
+			continue
+		}
+		for _, cells := range ds.Rows {
+			if cells[i].IsMissing {
+				hasMissing[v.Name()] = true
+				break
+			}
+		}
+	}
+
+	group := make(parquet.Group, len(ds.Vars)*2)
+	for _, v := range ds.Vars {
+		switch v.Type() {
+		case xpt.NUMERIC:
+			group[v.Name()] = parquet.Optional(parquet.Leaf(parquet.DoubleType))
+			if hasMissing[v.Name()] {
+				group[v.Name()+"_missing"] = parquet.Optional(parquet.String())
+			}
+		case xpt.CHARACTER:
+			group[v.Name()] = parquet.String()
+		}
+	}
+
+	schema := parquet.NewSchema("xpt", group)
+	pw := parquet.NewGenericWriter[map[string]any](w, schema)
+
+	for _, cells := range ds.Rows {
+		row := make(map[string]any, len(group))
+		for i, v := range ds.Vars {
+			d := cells[i]
+			switch v.Type() {
+			case xpt.NUMERIC:
+				if d.IsMissing {
+					row[v.Name()] = nil
+					if hasMissing[v.Name()] {
+						row[v.Name()+"_missing"] = ""
+						if d.MissingCode != 0 {
+							row[v.Name()+"_missing"] = string(missingCodeLabel(d.MissingCode))
+						}
+					}
+				} else {
+					row[v.Name()] = d.Float64()
+					if hasMissing[v.Name()] {
+						row[v.Name()+"_missing"] = ""
+					}
+				}
+			case xpt.CHARACTER:
+				row[v.Name()] = d.String()
+			}
+		}
+
+		if _, err := pw.Write([]map[string]any{row}); err != nil {
+			return err
+		}
+	}
+
+	return pw.Close()
+}
+
+// missingCodeLabel renders a DataCell.MissingCode back into its SAS notation:
+// "." for the default missing, "._" for the underscore special, and ".A"-".Z"
+// for the lettered specials.
+func missingCodeLabel(code byte) string {
+	if code == 0 {
+		return "."
+	}
+	return "." + string(code)
+}
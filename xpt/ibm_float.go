@@ -0,0 +1,128 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package xpt
+
+import "math"
+
+// missingCode reports whether b (an 8-byte numeric cell) encodes one of SAS's
+// special missing values: the first byte is '.' (0x2E), '_' (0x5F), or 'A'-'Z'
+// (0x41-0x5A) and the remaining seven bytes are zero. ok is false for an
+// ordinary IBM-float value. The returned code is 0 for a plain ".", matching
+// DataCell.MissingCode's convention.
+func missingCode(b []byte) (code byte, ok bool) {
+	if b[1]|b[2]|b[3]|b[4]|b[5]|b[6]|b[7] != 0 {
+		return 0, false
+	}
+
+	switch {
+	case b[0] == 0x2E:
+		return 0, true
+	case b[0] == 0x5F:
+		return '_', true
+	case b[0] >= 0x41 && b[0] <= 0x5A:
+		return b[0], true
+	default:
+		return 0, false
+	}
+}
+
+// encodeMissing builds the 8-byte special-missing encoding for code (0 for a
+// plain ".", '_' for "._", or 'A'-'Z' for ".A"-".Z"): the marker byte
+// followed by seven zero bytes. It is the inverse of missingCode.
+func encodeMissing(code byte) []byte {
+	b := make([]byte, 8)
+	if code == 0 {
+		b[0] = 0x2E
+	} else {
+		b[0] = code
+	}
+	return b
+}
+
+// XPT files are always stored as Big-endian
+// this function converts the IBM floating-point format to a float64
+func ibmFloat64(b []byte) float64 {
+	if len(b) != 8 {
+		panic("IBM float must be 8 bytes")
+	}
+
+	// all zero = 0.0
+	if b[0]|b[1]|b[2]|b[3]|b[4]|b[5]|b[6]|b[7] == 0 {
+		return 0
+	}
+
+	sign := (b[0] & 0x80) != 0
+	exponent := int(b[0]&0x7F) - 64
+
+	// fraction is base-16
+	var frac float64
+	for i := 1; i < 8; i++ {
+		frac += float64(b[i]) / math.Pow(256, float64(i))
+	}
+
+	val := frac * math.Pow(16, float64(exponent))
+	if sign {
+		val = -val
+	}
+	return val
+}
+
+// float64ToIBM is the inverse of ibmFloat64: it converts a float64 into the
+// 8-byte big-endian IBM hexadecimal floating-point representation XPORT uses,
+// normalizing the base-16 fraction and biasing the exponent by 64.
+func float64ToIBM(f float64) [8]byte {
+	var b [8]byte
+
+	if f == 0 {
+		return b
+	}
+
+	sign := f < 0
+	if sign {
+		f = -f
+	}
+
+	// find the smallest power-of-16 exponent such that f / 16^exponent lies in [1/16, 1)
+	exponent := 0
+	for f >= 1 {
+		f /= 16
+		exponent++
+	}
+	for f < 1.0/16 {
+		f *= 16
+		exponent--
+	}
+
+	// fraction is a 56-bit base-16 value spread across 7 bytes
+	frac := f
+	for i := 1; i < 8; i++ {
+		frac *= 256
+		byteVal := math.Floor(frac)
+		b[i] = byte(byteVal)
+		frac -= byteVal
+	}
+
+	biased := exponent + 64
+	b[0] = byte(biased & 0x7F)
+	if sign {
+		b[0] |= 0x80
+	}
+
+	return b
+}
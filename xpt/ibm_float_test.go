@@ -0,0 +1,47 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package xpt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIBMFloatRoundTrip(t *testing.T) {
+	values := []float64{0, 1, -1, 42, -42, 0.5, 123456.789, -0.0001, 1e10, -1e-10}
+
+	for _, want := range values {
+		b := float64ToIBM(want)
+		got := ibmFloat64(b[:])
+
+		if math.Abs(got-want) > math.Abs(want)*1e-9+1e-12 {
+			t.Errorf("float64ToIBM/ibmFloat64 round trip for %v = %v", want, got)
+		}
+	}
+}
+
+func TestIBMFloatZero(t *testing.T) {
+	b := float64ToIBM(0)
+	if b != ([8]byte{}) {
+		t.Errorf("float64ToIBM(0) = %v, want all-zero bytes", b)
+	}
+	if got := ibmFloat64(b[:]); got != 0 {
+		t.Errorf("ibmFloat64(all-zero) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package xpt
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestMissingValueAlphabetRoundTrip covers the full SAS missing-value
+// alphabet: the plain ".", the "._" underscore special, and the lettered
+// ".A"-".Z" specials. Each must survive WriteXPT/NewReader as IsMissing with
+// the matching MissingCode.
+func TestMissingValueAlphabetRoundTrip(t *testing.T) {
+	codes := []byte{0, '_', 'A', 'M', 'Z'}
+
+	ds := &Dataset{
+		Vars: []Variable{
+			NewVariable(1, "RESULT", "Result", 8, NUMERIC),
+		},
+	}
+	for _, code := range codes {
+		ds.Rows = append(ds.Rows, []DataCell{NewMissingCell(code)})
+	}
+
+	var buf bytes.Buffer
+	if err := WriteXPT(&buf, ds); err != nil {
+		t.Fatalf("WriteXPT: %v", err)
+	}
+
+	rdr, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	for i, wantCode := range codes {
+		row, err := rdr.Next()
+		if err != nil {
+			t.Fatalf("Next() row %d: %v", i, err)
+		}
+		if !row[0].IsMissing {
+			t.Errorf("row %d: IsMissing = false, want true", i)
+			continue
+		}
+		if row[0].MissingCode != wantCode {
+			t.Errorf("row %d: MissingCode = %q, want %q", i, row[0].MissingCode, wantCode)
+		}
+	}
+}
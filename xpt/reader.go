@@ -0,0 +1,342 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package xpt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Reader parses an XPT stream's headers eagerly, then hands back one
+// observation at a time via Next so a caller never has to hold an entire
+// (possibly multi-GB) dataset in memory. A Reader is only safe for use by
+// one goroutine at a time, but distinct Readers over distinct io.Readers are
+// fully independent: all parse state lives on the Reader, not a package
+// global, so multiple files can be read concurrently.
+type Reader struct {
+	br *bufio.Reader
+
+	descriptorSize int  // either 136 (VAX systems) or 140 bytes per NAMESTR record
+	dataRecordSize int  // how many bytes are occupied by one row of the dataset
+	longNames      bool // true once a LIBV8/MEMBV8 marker is seen
+
+	numObs   int // observation count stashed in the OBS marker's tail by WriteXPT; 0 if absent
+	rowsRead int // how many rows Next has already returned
+
+	vars []Variable
+
+	buf []byte // holds bytes read but not yet consumed by the current parse phase
+}
+
+// NewReader parses the LIBRARY/MEMBER/DSCRPTR/NAMESTR (and, if present,
+// LABELV8/LABELV9) header records from r and returns a Reader positioned at
+// the first observation. The returned error is whatever the underlying
+// reader or a malformed header produced, returned unwrapped.
+func NewReader(r io.Reader) (*Reader, error) {
+	rdr := &Reader{br: bufio.NewReader(r)}
+
+	if err := rdr.parseHeaders(); err != nil {
+		return nil, err
+	}
+
+	return rdr, nil
+}
+
+// Variables returns the parsed column metadata, in dataset order.
+func (rdr *Reader) Variables() []Variable {
+	return rdr.vars
+}
+
+// Next decodes and returns the next observation row, one DataCell per
+// Variable in Variables() order. It returns io.EOF once every row has been
+// read.
+func (rdr *Reader) Next() ([]DataCell, error) {
+	// WriteXPT stashes the real observation count in the OBS marker's tail
+	// (see writeObsRecords' caller), which lets Next stop on the exact row
+	// count instead of having to guess where padding starts from byte
+	// content alone - content-based guessing is ambiguous whenever a real
+	// row's encoded bytes happen to be all ASCII-blank (e.g. an all-empty
+	// CHARACTER row), since that's indistinguishable from record padding.
+	if rdr.numObs > 0 && rdr.rowsRead >= rdr.numObs {
+		return nil, io.EOF
+	}
+
+	for len(rdr.buf) < rdr.dataRecordSize {
+		rec, err := readRecord(rdr.br)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		rdr.buf = append(rdr.buf, rec...)
+	}
+
+	if len(rdr.buf) < rdr.dataRecordSize {
+		// not enough bytes left for a full row: trailing ASCII-blank padding
+		return nil, io.EOF
+	}
+
+	// Files without a usable stashed count (e.g. produced by something other
+	// than this package's WriteXPT) fall back to the old heuristic: once the
+	// underlying reader has nothing left to give, treat an all-blank
+	// candidate row as trailing padding rather than data. This is inherently
+	// ambiguous for all-blank real data, which is exactly why WriteXPT now
+	// stashes the count above.
+	if rdr.numObs == 0 && isAllBlank(rdr.buf[:rdr.dataRecordSize]) {
+		if _, err := rdr.br.Peek(1); err == io.EOF {
+			return nil, io.EOF
+		}
+	}
+
+	rdr.rowsRead++
+
+	row := make([]DataCell, len(rdr.vars))
+	for i := range rdr.vars {
+		v := &rdr.vars[i]
+		tmp := rdr.buf[:v.length]
+		rdr.buf = rdr.buf[v.length:]
+
+		var d DataCell
+		if v.vartype == NUMERIC {
+			if code, ok := missingCode(tmp); ok {
+				d.IsMissing = true
+				d.MissingCode = code
+			} else {
+				d.value_numeric = ibmFloat64(tmp)
+				d.value_char = fmt.Sprintf("%f", d.value_numeric)
+			}
+		} else {
+			d.value_char = strings.TrimSpace(string(tmp))
+		}
+
+		row[i] = d
+	}
+
+	return row, nil
+}
+
+// parseHeaders walks the header records with a small state machine, up to and
+// including the OBS marker. All state (descriptorSize, vars, the parse buffer)
+// lives on rdr instead of a package-level variable.
+func (rdr *Reader) parseHeaders() error {
+	currentState := NON_HEADER
+
+	for {
+		rec, err := readRecord(rdr.br)
+		if err != nil {
+			return err
+		}
+
+		rec_str := string(rec)
+
+		if !strings.Contains(rec_str, "HEADER RECORD*******") {
+			switch currentState {
+			case LIB_HEADER:
+				// nothing to extract yet; library metadata isn't surfaced on Reader
+			case MEM_HEADER:
+				// nothing to extract yet; member metadata isn't surfaced on Reader
+			case DES_HEADER:
+				// descriptor header carries no fields of interest
+			case NAM_HEADER:
+				rdr.parseNamRecord(rec)
+			case LBL_HEADER:
+				rdr.parseLblRecord(rec)
+			}
+			continue
+		}
+
+		switch {
+		case strings.Contains(rec_str, "HEADER RECORD*******LIBRARY HEADER RECORD!!!!!!!"):
+			currentState = LIB_HEADER
+		case strings.Contains(rec_str, "HEADER RECORD*******LIBV8   HEADER RECORD!!!!!!!"):
+			currentState = LIB_HEADER
+			rdr.longNames = true
+		case strings.Contains(rec_str, "HEADER RECORD*******MEMBER  HEADER RECORD!!!!!!!"):
+			currentState = MEM_HEADER
+			rdr.parseMemHeader(rec)
+		case strings.Contains(rec_str, "HEADER RECORD*******MEMBV8  HEADER RECORD!!!!!!!"):
+			currentState = MEM_HEADER
+			rdr.longNames = true
+			rdr.parseMemHeader(rec)
+		case strings.Contains(rec_str, "HEADER RECORD*******DSCRPTR HEADER RECORD!!!!!!!"):
+			currentState = DES_HEADER
+		case strings.Contains(rec_str, "HEADER RECORD*******NAMESTR HEADER RECORD!!!!!!!"):
+			currentState = NAM_HEADER
+			if err := rdr.parseNamHeader(rec); err != nil {
+				return err
+			}
+		case strings.Contains(rec_str, "HEADER RECORD*******LABELV8 HEADER RECORD!!!!!!!"),
+			strings.Contains(rec_str, "HEADER RECORD*******LABELV9 HEADER RECORD!!!!!!!"):
+			currentState = LBL_HEADER
+			rdr.longNames = true
+			rdr.buf = nil // discard NAMESTR padding remnants
+		case strings.Contains(rec_str, "HEADER RECORD*******OBS     HEADER RECORD!!!!!!!"):
+			rdr.parseObsHeader(rec)
+			rdr.calculateDataRecordSize()
+			rdr.buf = nil // discard NAMESTR/LABEL padding remnants
+			return nil
+		}
+	}
+}
+
+// isAllBlank reports whether b is nothing but ASCII blanks (the padding XPORT
+// uses to fill a record out to the 80-byte boundary).
+func isAllBlank(b []byte) bool {
+	for _, c := range b {
+		if c != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+func readRecord(r *bufio.Reader) ([]byte, error) {
+	buf := make([]byte, recordSize)
+	_, err := io.ReadFull(r, buf)
+
+	if err != nil {
+		return buf, err
+	}
+
+	return buf, nil
+}
+
+func (rdr *Reader) calculateDataRecordSize() {
+	for i := range rdr.vars {
+		rdr.dataRecordSize += rdr.vars[i].length
+	}
+}
+
+// parseObsHeader reads the observation count WriteXPT stashes in the OBS
+// marker's tail (headerMarkerSize:headerMarkerSize+8). A zero or non-numeric
+// tail - e.g. the default "00000000" fill a genuinely external XPORT writer
+// would leave, or any other file that didn't stash a count - leaves numObs at 0,
+// which Next treats as "unknown, fall back to the blank-padding heuristic".
+func (rdr *Reader) parseObsHeader(rec []byte) {
+	n, err := strconv.Atoi(strings.TrimSpace(string(rec[headerMarkerSize : headerMarkerSize+8])))
+	if err != nil {
+		return
+	}
+	rdr.numObs = n
+}
+
+func (rdr *Reader) parseMemHeader(rec []byte) {
+	// get the size of the variable descriptor record
+	// usually 140 bytes but 136 on VAX/VMS systems
+	desSize := string(rec[75:78])
+	if desSize == "140" {
+		rdr.descriptorSize = 140
+	} else {
+		rdr.descriptorSize = 136
+	}
+}
+
+func (rdr *Reader) parseNamHeader(rec []byte) error {
+	numOfVars, err := strconv.Atoi(string(rec[54:58]))
+	if err != nil {
+		return err
+	}
+
+	rdr.vars = make([]Variable, 0, numOfVars)
+	return nil
+}
+
+func (rdr *Reader) parseNamRecord(rec []byte) {
+	rdr.buf = append(rdr.buf, rec...)
+	for len(rdr.buf) >= rdr.descriptorSize {
+		// select 136/140 bytes, this is a full namestr record
+		// retain the remainder in the buffer until another full record is reached
+		tmp := rdr.buf[0:rdr.descriptorSize]
+		rdr.buf = rdr.buf[rdr.descriptorSize:]
+
+		nam := NameStrRecord{}
+		copy(nam.ntype[:], tmp[0:2])
+		copy(nam.nhfun[:], tmp[2:4])
+		copy(nam.nlng[:], tmp[4:6])
+		copy(nam.nvar0[:], tmp[6:8])
+		copy(nam.nname[:], tmp[8:16])
+		copy(nam.nlabel[:], tmp[16:56])
+		copy(nam.nform[:], tmp[56:64])
+		copy(nam.nfl[:], tmp[64:66])
+		copy(nam.nfd[:], tmp[66:68])
+		copy(nam.nfj[:], tmp[68:70])
+		copy(nam.nfill[:], tmp[70:72])
+		copy(nam.niform[:], tmp[72:80])
+		copy(nam.nifl[:], tmp[80:82])
+		copy(nam.nifd[:], tmp[82:84])
+		copy(nam.npos[:], tmp[84:86])
+		copy(nam.rest[:], tmp[86:])
+
+		// human friendly var, i.e., not just a bunch of bytes
+		v := Variable{}
+		v.varnum = int(binary.BigEndian.Uint16(nam.nvar0[:]))
+		v.length = int(binary.BigEndian.Uint16(nam.nlng[:]))
+		v.shortName = strings.TrimSpace(string(nam.nname[:]))
+		v.shortLabel = strings.TrimSpace(string(nam.nlabel[:]))
+		// under V8/V9 these are overwritten by the LABELV8/LABELV9 records that follow
+		v.name = v.shortName
+		v.label = v.shortLabel
+
+		if vartype := int(binary.BigEndian.Uint16(nam.ntype[:])); vartype == 1 {
+			v.vartype = NUMERIC
+		} else {
+			v.vartype = CHARACTER
+		}
+
+		rdr.vars = append(rdr.vars, v)
+	}
+}
+
+// parseLblRecord accumulates the variable-length LABELV8/LABELV9 entries that
+// follow the NAMESTR block under XPORT V8/V9: each entry is a 2-byte varnum,
+// a 2-byte name length, a 2-byte label length, then that many bytes of name
+// and label. Entries are packed back-to-back across 80-byte records exactly
+// like NAMESTR, so the trailing bytes of the final record are read as padding
+// once fewer than 6 bytes remain.
+func (rdr *Reader) parseLblRecord(rec []byte) {
+	rdr.buf = append(rdr.buf, rec...)
+
+	for len(rdr.buf) >= 6 {
+		varnum := int(binary.BigEndian.Uint16(rdr.buf[0:2]))
+		nameLen := int(binary.BigEndian.Uint16(rdr.buf[2:4]))
+		labelLen := int(binary.BigEndian.Uint16(rdr.buf[4:6]))
+		total := 6 + nameLen + labelLen
+
+		if len(rdr.buf) < total {
+			break
+		}
+
+		name := string(rdr.buf[6 : 6+nameLen])
+		label := string(rdr.buf[6+nameLen : total])
+		rdr.buf = rdr.buf[total:]
+
+		for i := range rdr.vars {
+			if rdr.vars[i].varnum == varnum {
+				rdr.vars[i].name = name
+				rdr.vars[i].label = label
+				break
+			}
+		}
+	}
+}
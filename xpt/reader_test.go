@@ -0,0 +1,111 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package xpt
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// TestReaderNextStopsAtPadding reproduces the phantom-row bug: with
+// dataRecordSize=18, two rows only take up 36 of the 80 bytes in the OBS
+// section's lone physical record, leaving 44 bytes of ASCII-blank padding -
+// more than enough to look like one more row. Next must return io.EOF there
+// instead of decoding the padding as a third observation.
+func TestReaderNextStopsAtPadding(t *testing.T) {
+	ds := &Dataset{
+		Vars: []Variable{
+			NewVariable(1, "AGE", "Age", 8, NUMERIC),
+			NewVariable(2, "NAME", "Name", 10, CHARACTER),
+		},
+		Rows: [][]DataCell{
+			{NewNumericCell(30), NewCharacterCell("Alice")},
+			{NewNumericCell(45), NewCharacterCell("Bob")},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteXPT(&buf, ds); err != nil {
+		t.Fatalf("WriteXPT: %v", err)
+	}
+
+	rdr, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	for i, want := range ds.Rows {
+		row, err := rdr.Next()
+		if err != nil {
+			t.Fatalf("Next() row %d: unexpected error %v", i, err)
+		}
+		if row[0].Float64() != want[0].Float64() {
+			t.Errorf("row %d AGE = %v, want %v", i, row[0].Float64(), want[0].Float64())
+		}
+		if row[1].String() != want[1].String() {
+			t.Errorf("row %d NAME = %q, want %q", i, row[1].String(), want[1].String())
+		}
+	}
+
+	if _, err := rdr.Next(); err != io.EOF {
+		t.Fatalf("Next() after last row = %v, want io.EOF", err)
+	}
+}
+
+// TestReaderNextKeepsAllBlankRows reproduces the companion bug to
+// TestReaderNextStopsAtPadding: a CHARACTER-only dataset whose rows happen to
+// all encode as ASCII blanks (an empty-string value, which is completely
+// normal SAS data) is indistinguishable from OBS padding by byte content
+// alone, and the whole OBS section fits in a single 80-byte record here. Next
+// must still return every real row instead of mistaking them for padding.
+func TestReaderNextKeepsAllBlankRows(t *testing.T) {
+	ds := &Dataset{
+		Vars: []Variable{
+			NewVariable(1, "NOTES", "Notes", 10, CHARACTER),
+		},
+	}
+	for i := 0; i < 6; i++ {
+		ds.Rows = append(ds.Rows, []DataCell{NewCharacterCell("")})
+	}
+
+	var buf bytes.Buffer
+	if err := WriteXPT(&buf, ds); err != nil {
+		t.Fatalf("WriteXPT: %v", err)
+	}
+
+	rdr, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	for i := range ds.Rows {
+		row, err := rdr.Next()
+		if err != nil {
+			t.Fatalf("Next() row %d: unexpected error %v", i, err)
+		}
+		if row[0].String() != "" {
+			t.Errorf("row %d NOTES = %q, want empty string", i, row[0].String())
+		}
+	}
+
+	if _, err := rdr.Next(); err != io.EOF {
+		t.Fatalf("Next() after last row = %v, want io.EOF", err)
+	}
+}
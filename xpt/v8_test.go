@@ -0,0 +1,72 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package xpt
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestLongNamesAndLabelsRoundTrip exercises the V8/V9 extension: a name/label
+// past the V5 NAMESTR limits (8/40 bytes) must push WriteXPT into emitting
+// LABELV8/LABELV9 records, and Reader must recover the full name/label from
+// them rather than the truncated NAMESTR copy.
+func TestLongNamesAndLabelsRoundTrip(t *testing.T) {
+	longName := "PATIENT_BASELINE_SYSTOLIC_BP"                                            // 28 bytes, over the 8-byte V5 limit
+	longLabel := "Patient's baseline systolic blood pressure reading at enrollment visit" // over the 40-byte V5 limit
+
+	ds := &Dataset{
+		Vars: []Variable{
+			NewVariable(1, longName, longLabel, 8, NUMERIC),
+			NewVariable(2, "SITE", "Site", 4, CHARACTER),
+		},
+		Rows: [][]DataCell{
+			{NewNumericCell(120), NewCharacterCell("A1")},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteXPT(&buf, ds); err != nil {
+		t.Fatalf("WriteXPT: %v", err)
+	}
+
+	rdr, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	vars := rdr.Variables()
+	if len(vars) != 2 {
+		t.Fatalf("Variables() returned %d vars, want 2", len(vars))
+	}
+	if vars[0].Name() != longName {
+		t.Errorf("Name() = %q, want %q", vars[0].Name(), longName)
+	}
+	if vars[0].Label() != longLabel {
+		t.Errorf("Label() = %q, want %q", vars[0].Label(), longLabel)
+	}
+
+	row, err := rdr.Next()
+	if err != nil {
+		t.Fatalf("Next(): %v", err)
+	}
+	if row[0].Float64() != 120 || row[1].String() != "A1" {
+		t.Errorf("row = %v/%q, want 120/A1", row[0].Float64(), row[1].String())
+	}
+}
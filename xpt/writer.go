@@ -0,0 +1,329 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package xpt
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// headerMarkerSize is the length, in bytes, of the "HEADER RECORD*******...!!!!!!!"
+// prefix shared by every XPORT marker record, before the trailing numeric/blank tail.
+const headerMarkerSize = 48
+
+// WriteXPTFile writes ds to path as a spec-compliant XPORT stream.
+func WriteXPTFile(path string, ds *Dataset) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return WriteXPT(f, ds)
+}
+
+// WriteXPT writes ds to w as a spec-compliant XPORT stream: the LIBRARY,
+// MEMBER, DSCRPTR, NAMESTR and OBS header records followed by the NAMESTR
+// descriptors and observation data, all packed into 80-byte records. It
+// automatically switches to the V8/V9 marker records and LABEL entries when
+// a variable's name or label exceeds the V5 8/40-byte limits.
+func WriteXPT(w io.Writer, ds *Dataset) error {
+	if ds.descriptorSize == 0 {
+		ds.descriptorSize = 140
+	}
+
+	libMarker, memMarker, lblMarker := markersFor(ds)
+
+	if err := writeHeaderMarker(w, libMarker, nil); err != nil {
+		return err
+	}
+	if err := writeLibRecord(w, ds); err != nil {
+		return err
+	}
+
+	if err := writeHeaderMarker(w, memMarker, func(tail []byte) {
+		copy(tail[27:30], []byte(fmt.Sprintf("%03d", ds.descriptorSize)))
+	}); err != nil {
+		return err
+	}
+	if err := writeHeaderMarker(w, "DSCRPTR", nil); err != nil {
+		return err
+	}
+	if err := writeMemRecord(w, ds); err != nil {
+		return err
+	}
+
+	if err := writeHeaderMarker(w, "NAMESTR", func(tail []byte) {
+		copy(tail[6:10], []byte(fmt.Sprintf("%04d", len(ds.Vars))))
+	}); err != nil {
+		return err
+	}
+	if err := writeNamRecords(w, ds); err != nil {
+		return err
+	}
+
+	if lblMarker != "" {
+		if err := writeHeaderMarker(w, lblMarker, nil); err != nil {
+			return err
+		}
+		if err := writeLblRecords(w, ds); err != nil {
+			return err
+		}
+	}
+
+	// The XPORT spec has no standard field for the observation count, which
+	// leaves a reader unable to tell a genuine final row from the ASCII-blank
+	// padding XPORT pads the last OBS record out to the 80-byte boundary with
+	// whenever a row happens to be all-blank data itself. Stash the real count
+	// in the OBS marker's otherwise-unused tail bytes, the same way the
+	// NAMESTR/MEMBER markers above stash the variable count/descriptor size,
+	// so Reader.Next can stop exactly on the real row count instead of
+	// guessing from byte content.
+	if err := writeHeaderMarker(w, "OBS    ", func(tail []byte) {
+		copy(tail[0:8], []byte(fmt.Sprintf("%08d", len(ds.Rows))))
+	}); err != nil {
+		return err
+	}
+	return writeObsRecords(w, ds)
+}
+
+// markersFor decides whether ds needs the V8 (names > 8 chars) or V9 (labels
+// > 40 chars) marker records, falling back to plain V5 when every variable
+// fits the short NAMESTR fields.
+func markersFor(ds *Dataset) (lib, mem, lbl string) {
+	needsV9 := false
+	needsV8 := false
+	for _, v := range ds.Vars {
+		if len(v.label) > 40 {
+			needsV9 = true
+		}
+		if len(v.name) > 8 {
+			needsV8 = true
+		}
+	}
+
+	switch {
+	case needsV9:
+		return "LIBV8  ", "MEMBV8 ", "LABELV9"
+	case needsV8:
+		return "LIBV8  ", "MEMBV8 ", "LABELV8"
+	default:
+		return "LIBRARY", "MEMBER ", ""
+	}
+}
+
+// writeHeaderMarker writes one of the "HEADER RECORD*******<name> HEADER RECORD!!!!!!!"
+// marker records. name must already be padded/truncated to 7 characters so the
+// marker lines up the way parseNamHeader/parseMemHeader expect when read back.
+// fillTail, if non-nil, is given the chance to stamp numeric fields (variable
+// count, descriptor size, ...) into the trailing bytes before they are padded.
+func writeHeaderMarker(w io.Writer, name string, fillTail func(tail []byte)) error {
+	rec := make([]byte, recordSize)
+	copy(rec, []byte(fmt.Sprintf("HEADER RECORD*******%s HEADER RECORD!!!!!!!", name)))
+
+	tail := rec[headerMarkerSize:]
+	for i := range tail {
+		tail[i] = '0'
+	}
+	if fillTail != nil {
+		fillTail(tail)
+	}
+
+	return writeRecord(w, rec)
+}
+
+func writeLibRecord(w io.Writer, ds *Dataset) error {
+	now := sasTimestamp(time.Now())
+
+	rec := make([]byte, recordSize)
+	fillBlank(rec)
+	copy(rec[0:8], padRight("SAS", 8))
+	copy(rec[8:16], padRight("SAS", 8))
+	copy(rec[16:24], padRight("SASLIB", 8))
+	copy(rec[24:32], padRight("9.4", 8))
+	copy(rec[32:40], padRight("LINUX", 8))
+	copy(rec[64:80], padRight(now, 16))
+	if err := writeRecord(w, rec); err != nil {
+		return err
+	}
+
+	// second real header record: same creation timestamp, repeated per spec
+	rec2 := make([]byte, recordSize)
+	fillBlank(rec2)
+	copy(rec2[64:80], padRight(now, 16))
+	return writeRecord(w, rec2)
+}
+
+func writeMemRecord(w io.Writer, ds *Dataset) error {
+	now := sasTimestamp(time.Now())
+
+	rec := make([]byte, recordSize)
+	fillBlank(rec)
+	copy(rec[0:8], padRight("SAS", 8))
+	copy(rec[8:16], padRight("DATASET", 8))
+	copy(rec[16:24], padRight("SASDATA", 8))
+	copy(rec[24:32], padRight("9.4", 8))
+	copy(rec[32:40], padRight("LINUX", 8))
+	copy(rec[64:80], padRight(now, 16))
+	if err := writeRecord(w, rec); err != nil {
+		return err
+	}
+
+	rec2 := make([]byte, recordSize)
+	fillBlank(rec2)
+	copy(rec2[0:16], padRight(now, 16))
+	copy(rec2[32:72], padRight("", 40))
+	copy(rec2[72:80], padRight("DATA", 8))
+	return writeRecord(w, rec2)
+}
+
+func writeNamRecords(w io.Writer, ds *Dataset) error {
+	var buf []byte
+
+	for _, v := range ds.Vars {
+		nam := make([]byte, ds.descriptorSize)
+		putUint16(nam[0:2], uint16(v.vartype)+1) // NUMERIC=1, CHARACTER=2
+		putUint16(nam[4:6], uint16(v.length))
+		putUint16(nam[6:8], uint16(v.varnum))
+		copy(nam[8:16], padRight(shortOf(v.name, 8), 8))
+		copy(nam[16:56], padRight(shortOf(v.label, 40), 40))
+
+		buf = append(buf, nam...)
+	}
+
+	// NAMESTR records are packed back-to-back into 80-byte records, the
+	// final one padded with ASCII blanks to the record boundary.
+	for len(buf) >= recordSize {
+		if err := writeRecord(w, buf[:recordSize]); err != nil {
+			return err
+		}
+		buf = buf[recordSize:]
+	}
+	if len(buf) > 0 {
+		return writeRecord(w, padRight(string(buf), recordSize))
+	}
+	return nil
+}
+
+// shortOf truncates s to the NAMESTR field width so it still has a value
+// under V8/V9, where the canonical name/label in the LABEL records may be longer.
+func shortOf(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+// writeLblRecords emits the LABELV8/LABELV9 entries: a 2-byte varnum, a
+// 2-byte name length, a 2-byte label length, then the name and label bytes
+// themselves, packed back-to-back across 80-byte records like NAMESTR.
+func writeLblRecords(w io.Writer, ds *Dataset) error {
+	var buf []byte
+
+	for _, v := range ds.Vars {
+		entry := make([]byte, 6+len(v.name)+len(v.label))
+		putUint16(entry[0:2], uint16(v.varnum))
+		putUint16(entry[2:4], uint16(len(v.name)))
+		putUint16(entry[4:6], uint16(len(v.label)))
+		copy(entry[6:6+len(v.name)], v.name)
+		copy(entry[6+len(v.name):], v.label)
+
+		buf = append(buf, entry...)
+	}
+
+	for len(buf) >= recordSize {
+		if err := writeRecord(w, buf[:recordSize]); err != nil {
+			return err
+		}
+		buf = buf[recordSize:]
+	}
+	if len(buf) > 0 {
+		return writeRecord(w, padRight(string(buf), recordSize))
+	}
+	return nil
+}
+
+func writeObsRecords(w io.Writer, ds *Dataset) error {
+	var buf []byte
+	for _, row := range ds.Rows {
+		for i := range ds.Vars {
+			v := &ds.Vars[i]
+			d := row[i]
+
+			if v.vartype == NUMERIC {
+				if d.IsMissing {
+					buf = append(buf, encodeMissing(d.MissingCode)...)
+				} else {
+					ibm := float64ToIBM(d.value_numeric)
+					buf = append(buf, ibm[:]...)
+				}
+			} else {
+				buf = append(buf, padRight(d.value_char, v.length)...)
+			}
+		}
+	}
+
+	for len(buf) >= recordSize {
+		if err := writeRecord(w, buf[:recordSize]); err != nil {
+			return err
+		}
+		buf = buf[recordSize:]
+	}
+	if len(buf) > 0 {
+		return writeRecord(w, padRight(string(buf), recordSize))
+	}
+	return nil
+}
+
+func writeRecord(w io.Writer, rec []byte) error {
+	_, err := w.Write(rec)
+	return err
+}
+
+// padRight space-pads (or truncates) s to exactly n bytes, the fixed-width
+// layout every XPORT character field and record uses.
+func padRight(s string, n int) []byte {
+	b := make([]byte, n)
+	fillBlank(b)
+	copy(b, s)
+	if len(s) > n {
+		copy(b, s[:n])
+	}
+	return b
+}
+
+func fillBlank(b []byte) {
+	for i := range b {
+		b[i] = ' '
+	}
+}
+
+func putUint16(b []byte, v uint16) {
+	b[0] = byte(v >> 8)
+	b[1] = byte(v)
+}
+
+func sasTimestamp(t time.Time) string {
+	// ddMONyy:hh:mm:ss, e.g. 16MAR26:14:30:00
+	month := strings.ToUpper(t.Format("Jan"))
+	return fmt.Sprintf("%02d%s%02d:%02d:%02d:%02d", t.Day(), month, t.Year()%100, t.Hour(), t.Minute(), t.Second())
+}
@@ -0,0 +1,198 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// Package xpt reads and writes XPORT (SAS Transport, "XPT") files: the
+// NAMESTR-described, big-endian, IBM-hexadecimal-float format SAS uses to
+// exchange datasets. It supports XPORT V5 as well as the V8/V9 extensions
+// for variable names and labels longer than the V5 8/40-byte limits.
+package xpt
+
+import "fmt"
+
+// all SAS records are 80 bytes in length and padded with
+// ASCII blanks where necessary to reach this length
+const recordSize = 80
+
+// states to keep track of XPORT headers
+type HeaderState int
+type VariableType int
+
+const (
+	NON_HEADER HeaderState = iota
+	LIB_HEADER
+	MEM_HEADER
+	DES_HEADER
+	NAM_HEADER
+	LBL_HEADER
+	OBS_HEADER
+)
+
+const (
+	NUMERIC VariableType = iota
+	CHARACTER
+)
+
+// header structs
+type LibraryRecord struct {
+	sas_symbol1 [8]byte
+	sas_symbol2 [8]byte
+	sas_lib     [8]byte
+	sas_ver     [8]byte
+	sas_os      [8]byte
+	blanks      [24]byte
+	sas_create  [16]byte
+}
+
+type MemberRecord struct {
+	sas_symbol [8]byte
+	sas_dsname [8]byte
+	sas_data   [8]byte
+	sas_ver    [8]byte
+	sas_os     [8]byte
+	blanks     [24]byte
+	sas_create [16]byte
+}
+
+type MemberRecord2 struct {
+	dtmod_day    [2]byte
+	dtmod_month  [3]byte
+	dtmod_year   [2]byte
+	dtmod_colon1 [1]byte
+	dtmod_hour   [2]byte
+	dtmod_colon2 [1]byte
+	dtmod_minute [2]byte
+	dtmod_colon3 [1]byte
+	dtmod_second [2]byte
+	padding      [16]byte
+	ds_label     [40]byte
+	ds_type      [8]byte
+}
+
+type NameStrRecord struct {
+	ntype  [2]byte
+	nhfun  [2]byte
+	nlng   [2]byte
+	nvar0  [2]byte
+	nname  [8]byte
+	nlabel [40]byte
+	nform  [8]byte
+	nfl    [2]byte
+	nfd    [2]byte
+	nfj    [2]byte
+	nfill  [2]byte
+	niform [8]byte
+	nifl   [2]byte
+	nifd   [2]byte
+	npos   [4]byte
+	rest   [52]byte
+}
+
+// Variable describes one column of an XPT dataset: its NAMESTR metadata plus,
+// under V8/V9, the long name/label carried in the auxiliary LABEL records.
+type Variable struct {
+	varnum     int
+	name       string // canonical name; up to 32 bytes under V8/V9, otherwise equal to shortName
+	shortName  string // 8-char name as stored in the NAMESTR record itself
+	label      string // canonical label; up to 256 bytes under V9, otherwise equal to shortLabel
+	shortLabel string // 40-char label as stored in the NAMESTR record itself
+	length     int
+	vartype    VariableType
+}
+
+// Name returns the variable's canonical name (up to 32 bytes under V8/V9).
+func (v Variable) Name() string { return v.name }
+
+// Label returns the variable's canonical label (up to 256 bytes under V9).
+func (v Variable) Label() string { return v.label }
+
+// Length returns the fixed width, in bytes, of the variable's data cells.
+func (v Variable) Length() int { return v.length }
+
+// Type returns whether the variable holds NUMERIC or CHARACTER values.
+func (v Variable) Type() VariableType { return v.vartype }
+
+// VarNum returns the variable's 1-based position in the dataset.
+func (v Variable) VarNum() int { return v.varnum }
+
+// NewVariable builds a Variable for use in a *Dataset handed to WriteXPT.
+// name/label over the V5 NAMESTR limits (8/40 bytes) automatically push
+// WriteXPT into emitting V8/V9 output; shortName/shortLabel are derived by
+// truncation, matching what a V8/V9 reader would see in the NAMESTR record
+// itself.
+func NewVariable(varnum int, name, label string, length int, vartype VariableType) Variable {
+	return Variable{
+		varnum:     varnum,
+		name:       name,
+		shortName:  shortOf(name, 8),
+		label:      label,
+		shortLabel: shortOf(label, 40),
+		length:     length,
+		vartype:    vartype,
+	}
+}
+
+// DataCell is a single observation value for one Variable. A numeric cell
+// that holds one of SAS's special missing values has IsMissing set instead
+// of a usable value_numeric: MissingCode is 0 for a plain ".", '_' for "._",
+// or 'A'-'Z' for ".A" through ".Z".
+type DataCell struct {
+	value_numeric float64
+	value_char    string
+	IsMissing     bool
+	MissingCode   byte
+}
+
+// Float64 returns a NUMERIC cell's value. It is 0 when IsMissing is set.
+func (d DataCell) Float64() float64 { return d.value_numeric }
+
+// String returns a CHARACTER cell's value, or a NUMERIC cell's value
+// formatted as text.
+func (d DataCell) String() string { return d.value_char }
+
+// NewNumericCell builds a DataCell for a NUMERIC Variable.
+func NewNumericCell(value float64) DataCell {
+	return DataCell{value_numeric: value, value_char: fmt.Sprintf("%f", value)}
+}
+
+// NewCharacterCell builds a DataCell for a CHARACTER Variable.
+func NewCharacterCell(value string) DataCell {
+	return DataCell{value_char: value}
+}
+
+// NewMissingCell builds a NUMERIC DataCell holding one of SAS's special
+// missing values: 0 for a plain ".", '_' for "._", or 'A'-'Z' for ".A"-".Z".
+func NewMissingCell(code byte) DataCell {
+	return DataCell{IsMissing: true, MissingCode: code}
+}
+
+// Dataset is an in-memory XPT dataset: variable metadata plus every
+// observation row, in Vars order. It is the shape WriteXPT/WriteXPTFile
+// expect; Reader.Next streams rows one at a time instead for callers who
+// don't want to hold a whole dataset in memory.
+type Dataset struct {
+	descriptorSize int  // either 136 (VAX systems) or 140 bytes per NAMESTR record
+	numOfVars      int  // how many variables are expected in the dataset
+	dataRecordSize int  // how many bytes are occupied by one row of the dataset
+	longNames      bool // not set by this package; WriteXPT picks V5 or V8/V9 output from the names/labels themselves
+	LibRec         LibraryRecord
+	MemRec1        MemberRecord
+	MemRec2        MemberRecord2
+	NamRecs        []NameStrRecord
+	Vars           []Variable
+	Rows           [][]DataCell
+}
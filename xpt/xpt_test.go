@@ -0,0 +1,77 @@
+/*
+	go-xpt: an open-source, Go solution to reading/writing XPT (SAS Transport) files.
+    Copyright (C) 2026  Jan van der Linde
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package xpt
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// TestBuildDatasetFromScratch exercises the builder path WriteXPT's own docs
+// promise: a *Dataset assembled purely from NewVariable/NewNumericCell/
+// NewCharacterCell, with no prior Reader involved, should write and read back
+// correctly.
+func TestBuildDatasetFromScratch(t *testing.T) {
+	ds := &Dataset{
+		Vars: []Variable{
+			NewVariable(1, "WEIGHT", "Weight in kg", 8, NUMERIC),
+			NewVariable(2, "CITY", "City", 12, CHARACTER),
+		},
+		Rows: [][]DataCell{
+			{NewNumericCell(72.5), NewCharacterCell("Paris")},
+			{NewMissingCell(0), NewCharacterCell("Berlin")},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteXPT(&buf, ds); err != nil {
+		t.Fatalf("WriteXPT: %v", err)
+	}
+
+	rdr, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	vars := rdr.Variables()
+	if len(vars) != 2 || vars[0].Name() != "WEIGHT" || vars[1].Name() != "CITY" {
+		t.Fatalf("Variables() = %+v, want WEIGHT, CITY", vars)
+	}
+
+	row, err := rdr.Next()
+	if err != nil {
+		t.Fatalf("Next() row 0: %v", err)
+	}
+	if row[0].Float64() != 72.5 || row[1].String() != "Paris" {
+		t.Errorf("row 0 = %v/%q, want 72.5/Paris", row[0].Float64(), row[1].String())
+	}
+
+	row, err = rdr.Next()
+	if err != nil {
+		t.Fatalf("Next() row 1: %v", err)
+	}
+	if !row[0].IsMissing || row[1].String() != "Berlin" {
+		t.Errorf("row 1 = missing=%v/%q, want missing=true/Berlin", row[0].IsMissing, row[1].String())
+	}
+
+	if _, err := rdr.Next(); err != io.EOF {
+		t.Fatalf("Next() after last row = %v, want io.EOF", err)
+	}
+}